@@ -0,0 +1,193 @@
+// port-scanner/cmd/port-scanner/main.go
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/M-Fareed-PT/go-port-scanner/scanner"
+)
+
+func main() {
+	configPath := flag.String("config", "", "Load settings from an INI file; CLI flags still take precedence")
+	profileName := flag.String("profile", "", "Select a [profile \"name\"] section from --config")
+	host := flag.String("host", "", "Target host (IP or hostname); use --targets for multiple hosts/CIDRs/files")
+	targetsSpec := flag.String("targets", "", "Comma-separated targets: hosts, CIDR blocks (10.0.0.0/24), and @file.txt references")
+	ports := flag.String("ports", "1-1024", "Ports (e.g., 22,80,443 or 1-65535 or 22,80,8000-8100)")
+	concurrency := flag.Int("c", 200, "Concurrency (workers)")
+	timeoutMS := flag.Int("t", 300, "Dial timeout in ms")
+	bannerBytes := flag.Int("b", 128, "Banner read bytes (0 to skip)")
+	outFile := flag.String("o", "scan_results.json", "Output file: JSON array, or NDJSON with --jsonl")
+	jsonl := flag.Bool("jsonl", false, "Stream one ScanResult per line as results arrive, instead of buffering a JSON array")
+	resume := flag.Bool("resume", false, "Skip (host,port) pairs already recorded in the checkpoint file and resume an interrupted scan")
+	checkpointFile := flag.String("checkpoint", "scan_checkpoint.txt", "Checkpoint file used by --resume")
+	probesFlag := flag.String("probes", "", "Comma-separated active probers to run on their well-known ports: http,tls,ssh,redis,smb")
+	scanType := flag.String("scan-type", "connect", "Scan mode: connect, syn, or udp")
+	rateFlag := flag.Float64("rate", 0, "Global dial rate limit in packets/sec (0 = unlimited)")
+	perHostRateFlag := flag.Float64("per-host-rate", 0, "Per-host dial rate limit in packets/sec (0 = unlimited)")
+	adaptive := flag.Bool("adaptive", false, "Adapt --per-host-rate to each host's dial timeout ratio (AIMD)")
+	flag.Parse()
+
+	opts := scanner.DefaultOptions()
+	opts.AllowFileTargets = true // trusted CLI path: --targets=@file.txt may read local files
+	scanner.ApplyEnv(&opts)
+	if *configPath != "" {
+		ini, err := scanner.LoadINIFile(*configPath)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		ini.ApplyGlobal(&opts)
+		if *profileName != "" {
+			if err := ini.ApplyProfile(*profileName, &opts); err != nil {
+				fmt.Println(err)
+				return
+			}
+		}
+	} else if *profileName != "" {
+		fmt.Println("--profile requires --config")
+		return
+	}
+	applyExplicitFlags(&opts, host, targetsSpec, ports, concurrency, timeoutMS, bannerBytes, outFile, jsonl, resume, checkpointFile, probesFlag, scanType, rateFlag, perHostRateFlag, adaptive)
+
+	if opts.Targets == "" && opts.Host == "" {
+		fmt.Println("host or targets required")
+		flag.Usage()
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results, err := scanner.New(opts).Run(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var jsonlWriter *bufio.Writer
+	var jsonlFile *os.File
+	if opts.JSONL {
+		if opts.OutFile == "-" || opts.OutFile == "" {
+			jsonlWriter = bufio.NewWriter(os.Stdout)
+		} else {
+			jsonlFile, err = os.Create(opts.OutFile)
+			if err != nil {
+				fmt.Println("error creating output:", err)
+				return
+			}
+			defer jsonlFile.Close()
+			jsonlWriter = bufio.NewWriter(jsonlFile)
+		}
+	}
+
+	out := make([]scanner.ScanResult, 0)
+	for r := range results {
+		if jsonlWriter != nil {
+			line, err := json.Marshal(r)
+			if err != nil {
+				fmt.Println("error encoding result:", err)
+				continue
+			}
+			jsonlWriter.Write(line)
+			jsonlWriter.WriteString("\n")
+			jsonlWriter.Flush()
+		} else {
+			out = append(out, r)
+		}
+
+		// live console output for feedback
+		if r.State == scanner.StateOpen {
+			if r.Service != "" {
+				fmt.Printf("[OPEN] %s:%d service=%s details=%v\n", r.Host, r.Port, r.Service, r.Details)
+			} else {
+				fmt.Printf("[OPEN] %s:%d banner=%s\n", r.Host, r.Port, r.Banner)
+			}
+		}
+	}
+
+	if jsonlWriter != nil {
+		fmt.Println("Scan complete (jsonl stream).")
+		return
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Host != out[j].Host {
+			return out[i].Host < out[j].Host
+		}
+		return out[i].Port < out[j].Port
+	})
+
+	f, err := os.Create(opts.OutFile)
+	if err != nil {
+		fmt.Println("error creating output:", err)
+		return
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		fmt.Println("error writing json:", err)
+		return
+	}
+	f.Close()
+	fmt.Printf("Scan complete. Results saved to %s\n", opts.OutFile)
+}
+
+// applyExplicitFlags overlays only the flags the user actually typed on
+// the command line, so CLI wins over env/file but doesn't clobber them
+// with unrelated flag defaults.
+func applyExplicitFlags(opts *scanner.Options, host, targets, ports *string, concurrency, timeoutMS, bannerBytes *int, outFile *string, jsonl, resume *bool, checkpointFile, probes, scanType *string, rate, perHostRate *float64, adaptive *bool) {
+	visited := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { visited[f.Name] = true })
+
+	if visited["host"] {
+		opts.Host = *host
+	}
+	if visited["targets"] {
+		opts.Targets = *targets
+	}
+	if visited["ports"] {
+		opts.Ports = *ports
+	}
+	if visited["c"] {
+		opts.Concurrency = *concurrency
+	}
+	if visited["t"] {
+		opts.TimeoutMS = *timeoutMS
+	}
+	if visited["b"] {
+		opts.BannerBytes = *bannerBytes
+	}
+	if visited["o"] {
+		opts.OutFile = *outFile
+	}
+	if visited["jsonl"] {
+		opts.JSONL = *jsonl
+	}
+	if visited["resume"] {
+		opts.Resume = *resume
+	}
+	if visited["checkpoint"] {
+		opts.CheckpointFile = *checkpointFile
+	}
+	if visited["probes"] {
+		opts.Probes = *probes
+	}
+	if visited["scan-type"] {
+		opts.ScanType = *scanType
+	}
+	if visited["rate"] {
+		opts.Rate = *rate
+	}
+	if visited["per-host-rate"] {
+		opts.PerHostRate = *perHostRate
+	}
+	if visited["adaptive"] {
+		opts.Adaptive = *adaptive
+	}
+}
@@ -0,0 +1,345 @@
+// port-scanner/cmd/scannerd/main.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/M-Fareed-PT/go-port-scanner/scanner"
+)
+
+// scanRequest is the subset of scanner.Options a client can set over the
+// control API. A nil field falls back to whatever scanner.DefaultOptions
+// already set, so a client only needs to send the fields it cares about.
+type scanRequest struct {
+	Host        *string  `json:"host"`
+	Targets     *string  `json:"targets"`
+	Ports       *string  `json:"ports"`
+	ScanType    *string  `json:"scan_type"`
+	Concurrency *int     `json:"concurrency"`
+	TimeoutMS   *int     `json:"timeout_ms"`
+	BannerBytes *int     `json:"banner_bytes"`
+	Probes      *string  `json:"probes"`
+	Rate        *float64 `json:"rate"`
+	PerHostRate *float64 `json:"per_host_rate"`
+	Adaptive    *bool    `json:"adaptive"`
+}
+
+func (r scanRequest) applyTo(opts *scanner.Options) {
+	if r.Host != nil {
+		opts.Host = *r.Host
+	}
+	if r.Targets != nil {
+		opts.Targets = *r.Targets
+	}
+	if r.Ports != nil {
+		opts.Ports = *r.Ports
+	}
+	if r.ScanType != nil {
+		opts.ScanType = *r.ScanType
+	}
+	if r.Concurrency != nil {
+		opts.Concurrency = *r.Concurrency
+	}
+	if r.TimeoutMS != nil {
+		opts.TimeoutMS = *r.TimeoutMS
+	}
+	if r.BannerBytes != nil {
+		opts.BannerBytes = *r.BannerBytes
+	}
+	if r.Probes != nil {
+		opts.Probes = *r.Probes
+	}
+	if r.Rate != nil {
+		opts.Rate = *r.Rate
+	}
+	if r.PerHostRate != nil {
+		opts.PerHostRate = *r.PerHostRate
+	}
+	if r.Adaptive != nil {
+		opts.Adaptive = *r.Adaptive
+	}
+}
+
+// Caps on resource-intensive scanRequest fields. The trusted CLI has no
+// such ceiling, but any caller that can reach POST /scans could otherwise
+// pick a Concurrency/Rate/PerHostRate large enough to exhaust the whole
+// process's file descriptors or memory, taking down every other in-flight
+// scan along with its own.
+const (
+	maxNetworkConcurrency = 1000
+	maxNetworkRate        = 10000
+	maxNetworkPerHostRate = 10000
+)
+
+// validateNetworkOpts rejects Options values that are only reachable from
+// the network API and would let a single request exhaust shared server
+// resources.
+func validateNetworkOpts(opts scanner.Options) error {
+	if opts.Concurrency > maxNetworkConcurrency {
+		return fmt.Errorf("concurrency must be %d or less", maxNetworkConcurrency)
+	}
+	if opts.Rate > maxNetworkRate {
+		return fmt.Errorf("rate must be %v or less", maxNetworkRate)
+	}
+	if opts.PerHostRate > maxNetworkPerHostRate {
+		return fmt.Errorf("per_host_rate must be %v or less", maxNetworkPerHostRate)
+	}
+	return nil
+}
+
+type scanJobStatus string
+
+const (
+	scanJobRunning scanJobStatus = "running"
+	scanJobDone    scanJobStatus = "done"
+	scanJobError   scanJobStatus = "error"
+)
+
+// resultStreamBuffer bounds how many results a slow /results subscriber can
+// fall behind by before new ones are dropped for it. The subscriber's
+// buffered results (job.Results, visible via GET /scans/{id}) are never
+// lost — this only trades off how real-time the streaming view can stay.
+const resultStreamBuffer = 64
+
+// scanJob tracks one in-flight or completed scan started via the control
+// API. Results accumulate in Results for GET /scans/{id} to poll, and are
+// also fanned out live to any GET /scans/{id}/results subscribers.
+type scanJob struct {
+	mu          sync.Mutex
+	Status      scanJobStatus        `json:"status"`
+	Results     []scanner.ScanResult `json:"results,omitempty"`
+	Error       string               `json:"error,omitempty"`
+	subscribers []chan scanner.ScanResult
+}
+
+// subscribe returns a channel that replays every result seen so far,
+// followed by each new one as it arrives, and is closed once the scan
+// finishes. Callers must unsubscribe once done reading.
+func (j *scanJob) subscribe() chan scanner.ScanResult {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	ch := make(chan scanner.ScanResult, len(j.Results)+resultStreamBuffer)
+	for _, r := range j.Results {
+		ch <- r
+	}
+	if j.Status == scanJobRunning {
+		j.subscribers = append(j.subscribers, ch)
+	} else {
+		close(ch)
+	}
+	return ch
+}
+
+func (j *scanJob) unsubscribe(ch chan scanner.ScanResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for i, s := range j.subscribers {
+		if s == ch {
+			j.subscribers = append(j.subscribers[:i], j.subscribers[i+1:]...)
+			break
+		}
+	}
+}
+
+// jobStore holds every scan started since the server came up, keyed by an
+// incrementing ID. It is not persisted; restarting scannerd forgets it.
+type jobStore struct {
+	mu   sync.Mutex
+	next int
+	jobs map[string]*scanJob
+}
+
+func newJobStore() *jobStore {
+	return &jobStore{jobs: make(map[string]*scanJob)}
+}
+
+func (s *jobStore) create() (string, *scanJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	id := strconv.Itoa(s.next)
+	job := &scanJob{Status: scanJobRunning}
+	s.jobs[id] = job
+	return id, job
+}
+
+func (s *jobStore) get(id string) (*scanJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	return job, ok
+}
+
+// controlServer exposes a minimal HTTP API over a Scanner: start a scan,
+// then poll it for results. Scans run against rootCtx rather than an
+// individual request's context, so they keep running after the HTTP
+// handler that started them returns.
+type controlServer struct {
+	jobs    *jobStore
+	rootCtx context.Context
+}
+
+func (c *controlServer) handleCreateScan(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var sr scanRequest
+	if err := json.NewDecoder(req.Body).Decode(&sr); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	opts := scanner.DefaultOptions()
+	sr.applyTo(&opts)
+	// opts.AllowFileTargets stays false: a network caller must never be able
+	// to point Targets/Host at an @file reference and have it read back
+	// local file content as scan "hosts".
+
+	if err := validateNetworkOpts(opts); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, job := c.jobs.create()
+	results, err := scanner.New(opts).Run(c.rootCtx)
+	if err != nil {
+		job.mu.Lock()
+		job.Status = scanJobError
+		job.Error = err.Error()
+		job.mu.Unlock()
+	} else {
+		go collectResults(job, results)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"id": id})
+}
+
+func collectResults(job *scanJob, results <-chan scanner.ScanResult) {
+	for r := range results {
+		job.mu.Lock()
+		job.Results = append(job.Results, r)
+		for _, ch := range job.subscribers {
+			select {
+			case ch <- r:
+			default: // subscriber fell behind resultStreamBuffer; drop for it
+			}
+		}
+		job.mu.Unlock()
+	}
+
+	job.mu.Lock()
+	job.Status = scanJobDone
+	for _, ch := range job.subscribers {
+		close(ch)
+	}
+	job.subscribers = nil
+	job.mu.Unlock()
+}
+
+// handleScanByID dispatches GET /scans/{id} and GET /scans/{id}/results to
+// their respective handlers based on the path suffix.
+func (c *controlServer) handleScanByID(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id := strings.TrimPrefix(req.URL.Path, "/scans/")
+	if streamID, ok := strings.CutSuffix(id, "/results"); ok {
+		c.handleStreamResults(w, req, streamID)
+		return
+	}
+	c.handleGetScan(w, req, id)
+}
+
+func (c *controlServer) handleGetScan(w http.ResponseWriter, _ *http.Request, id string) {
+	if id == "" {
+		http.Error(w, "missing scan id", http.StatusBadRequest)
+		return
+	}
+	job, ok := c.jobs.get(id)
+	if !ok {
+		http.Error(w, "scan not found", http.StatusNotFound)
+		return
+	}
+
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// handleStreamResults streams a scan's results as newline-delimited JSON,
+// flushing after each one as it arrives rather than waiting for the scan to
+// finish. A request against an already-finished scan still streams (it
+// replays every result already collected, then closes).
+func (c *controlServer) handleStreamResults(w http.ResponseWriter, req *http.Request, id string) {
+	if id == "" {
+		http.Error(w, "missing scan id", http.StatusBadRequest)
+		return
+	}
+	job, ok := c.jobs.get(id)
+	if !ok {
+		http.Error(w, "scan not found", http.StatusNotFound)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := job.subscribe()
+	defer job.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Transfer-Encoding", "chunked")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case r, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := enc.Encode(r); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+func main() {
+	addr := flag.String("listen", ":8090", "Address for the HTTP control server to listen on")
+	flag.Parse()
+
+	srv := &controlServer{jobs: newJobStore(), rootCtx: context.Background()}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/scans", srv.handleCreateScan)
+	mux.HandleFunc("/scans/", srv.handleScanByID)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	fmt.Printf("scannerd listening on %s\n", *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		fmt.Println("scannerd:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,279 @@
+// port-scanner/cmd/scannerd/main_test.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/M-Fareed-PT/go-port-scanner/scanner"
+)
+
+func newTestServer() *controlServer {
+	return &controlServer{jobs: newJobStore(), rootCtx: context.Background()}
+}
+
+// waitForJob polls the job store until the job leaves scanJobRunning or the
+// timeout elapses, and returns its final status.
+func waitForJob(t *testing.T, job *scanJob, timeout time.Duration) scanJobStatus {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		job.mu.Lock()
+		status := job.Status
+		job.mu.Unlock()
+		if status != scanJobRunning {
+			return status
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("scan job did not finish before timeout")
+	return ""
+}
+
+func TestHandleCreateScanAndGetScan(t *testing.T) {
+	srv := newTestServer()
+	body := strings.NewReader(`{"host":"127.0.0.1","ports":"1","timeout_ms":50,"concurrency":1}`)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/scans", body)
+	srv.handleCreateScan(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("POST /scans status = %d, want %d; body=%s", w.Code, http.StatusAccepted, w.Body.String())
+	}
+	var created map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decoding create response: %v", err)
+	}
+	id := created["id"]
+	if id == "" {
+		t.Fatal("create response has no id")
+	}
+
+	job, ok := srv.jobs.get(id)
+	if !ok {
+		t.Fatalf("job %q not found in store after create", id)
+	}
+	waitForJob(t, job, 2*time.Second)
+
+	getW := httptest.NewRecorder()
+	getReq := httptest.NewRequest(http.MethodGet, "/scans/"+id, nil)
+	srv.handleScanByID(getW, getReq)
+
+	if getW.Code != http.StatusOK {
+		t.Fatalf("GET /scans/%s status = %d, want %d", id, getW.Code, http.StatusOK)
+	}
+	var got scanJob
+	if err := json.Unmarshal(getW.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding get response: %v", err)
+	}
+	if got.Status != scanJobDone {
+		t.Errorf("Status = %q, want %q", got.Status, scanJobDone)
+	}
+	if len(got.Results) != 1 || got.Results[0].Host != "127.0.0.1" {
+		t.Errorf("Results = %+v, want one result for 127.0.0.1", got.Results)
+	}
+}
+
+func TestHandleCreateScanRejectsOversizedConcurrency(t *testing.T) {
+	srv := newTestServer()
+	body := strings.NewReader(`{"host":"127.0.0.1","concurrency":1000000}`)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/scans", body)
+	srv.handleCreateScan(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d; body=%s", w.Code, http.StatusBadRequest, w.Body.String())
+	}
+}
+
+func TestHandleCreateScanRejectsFileTargets(t *testing.T) {
+	srv := newTestServer()
+	body := strings.NewReader(`{"targets":"@/etc/passwd","ports":"1"}`)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/scans", body)
+	srv.handleCreateScan(w, req)
+
+	// Scanner.New(opts).Run rejects the @file target inside Run, so the
+	// create call itself still reports 202 with a job that immediately
+	// errors out — the same path any other invalid Options value takes.
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d; body=%s", w.Code, http.StatusAccepted, w.Body.String())
+	}
+	var created map[string]string
+	json.Unmarshal(w.Body.Bytes(), &created)
+	job, ok := srv.jobs.get(created["id"])
+	if !ok {
+		t.Fatal("job not found after create")
+	}
+	status := waitForJob(t, job, time.Second)
+	if status != scanJobError {
+		t.Fatalf("Status = %q, want %q", status, scanJobError)
+	}
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if !strings.Contains(job.Error, "not allowed") {
+		t.Errorf("Error = %q, want it to mention file targets are not allowed", job.Error)
+	}
+}
+
+func TestHandleCreateScanInvalidBody(t *testing.T) {
+	srv := newTestServer()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/scans", strings.NewReader("not json"))
+	srv.handleCreateScan(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleGetScanNotFound(t *testing.T) {
+	srv := newTestServer()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/scans/does-not-exist", nil)
+	srv.handleScanByID(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleScanByIDMethodNotAllowed(t *testing.T) {
+	srv := newTestServer()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/scans/1", nil)
+	srv.handleScanByID(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandleStreamResultsReplaysAndCompletes(t *testing.T) {
+	srv := newTestServer()
+	id, job := srv.jobs.create()
+	job.mu.Lock()
+	job.Results = []scanner.ScanResult{{Host: "10.0.0.1", Port: 80, State: scanner.StateOpen}}
+	job.Status = scanJobDone
+	job.mu.Unlock()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/scans/"+id+"/results", nil)
+	srv.handleScanByID(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+	}
+
+	var got scanner.ScanResult
+	sc := bufio.NewScanner(bytes.NewReader(w.Body.Bytes()))
+	if !sc.Scan() {
+		t.Fatal("expected at least one NDJSON line in the stream")
+	}
+	if err := json.Unmarshal(sc.Bytes(), &got); err != nil {
+		t.Fatalf("decoding NDJSON line: %v", err)
+	}
+	if got.Host != "10.0.0.1" || got.Port != 80 {
+		t.Errorf("streamed result = %+v, want host 10.0.0.1 port 80", got)
+	}
+}
+
+func TestHandleStreamResultsNotFound(t *testing.T) {
+	srv := newTestServer()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/scans/nope/results", nil)
+	srv.handleScanByID(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestJobStoreCreateAndGet(t *testing.T) {
+	s := newJobStore()
+	id1, job1 := s.create()
+	id2, job2 := s.create()
+
+	if id1 == id2 {
+		t.Fatalf("create returned the same id twice: %q", id1)
+	}
+	if got, ok := s.get(id1); !ok || got != job1 {
+		t.Errorf("get(%q) = %v, %v; want %v, true", id1, got, ok, job1)
+	}
+	if got, ok := s.get(id2); !ok || got != job2 {
+		t.Errorf("get(%q) = %v, %v; want %v, true", id2, got, ok, job2)
+	}
+	if _, ok := s.get("missing"); ok {
+		t.Error("get should report false for an unknown id")
+	}
+}
+
+func TestScanJobSubscribeFanOutAndClose(t *testing.T) {
+	job := &scanJob{Status: scanJobRunning}
+	results := make(chan scanner.ScanResult)
+
+	done := make(chan struct{})
+	go func() {
+		collectResults(job, results)
+		close(done)
+	}()
+
+	ch := job.subscribe()
+	results <- scanner.ScanResult{Host: "h1", Port: 1}
+	select {
+	case r := <-ch:
+		if r.Host != "h1" {
+			t.Errorf("received %+v, want host h1", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for fanned-out result")
+	}
+
+	close(results)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("collectResults did not finish after its input channel closed")
+	}
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("subscriber channel should be closed once the job is done")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscriber channel was never closed")
+	}
+}
+
+func TestValidateNetworkOpts(t *testing.T) {
+	cases := []struct {
+		name    string
+		opts    scanner.Options
+		wantErr bool
+	}{
+		{"within limits", scanner.Options{Concurrency: 10, Rate: 5, PerHostRate: 5}, false},
+		{"oversized concurrency", scanner.Options{Concurrency: maxNetworkConcurrency + 1}, true},
+		{"oversized rate", scanner.Options{Rate: maxNetworkRate + 1}, true},
+		{"oversized per-host rate", scanner.Options{PerHostRate: maxNetworkPerHostRate + 1}, true},
+	}
+	for _, c := range cases {
+		err := validateNetworkOpts(c.opts)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: validateNetworkOpts error = %v, wantErr %v", c.name, err, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// port-scanner/scanner/checkpoint.go
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// checkpointKey is the (host,port) identity recorded in a checkpoint file.
+func checkpointKey(host string, port int) string {
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+// loadCheckpoint reads the set of already-completed (host,port) pairs from
+// a checkpoint file written by a previous, interrupted run. A missing file
+// is not an error — it just means nothing has completed yet.
+func loadCheckpoint(path string) (map[string]struct{}, error) {
+	done := make(map[string]struct{})
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return done, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line != "" {
+			done[line] = struct{}{}
+		}
+	}
+	return done, sc.Err()
+}
+
+// checkpointWriter appends completed (host,port) keys to a checkpoint file
+// as results arrive, so a killed scan can be resumed with --resume.
+type checkpointWriter struct {
+	f *os.File
+	w *bufio.Writer
+}
+
+func openCheckpointWriter(path string) (*checkpointWriter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening checkpoint file: %w", err)
+	}
+	return &checkpointWriter{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+func (c *checkpointWriter) record(host string, port int) error {
+	if _, err := c.w.WriteString(checkpointKey(host, port) + "\n"); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+func (c *checkpointWriter) Close() error {
+	if err := c.w.Flush(); err != nil {
+		c.f.Close()
+		return err
+	}
+	return c.f.Close()
+}
@@ -0,0 +1,48 @@
+// port-scanner/scanner/checkpoint_test.go
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.txt")
+
+	w, err := openCheckpointWriter(path)
+	if err != nil {
+		t.Fatalf("openCheckpointWriter: %v", err)
+	}
+	if err := w.record("10.0.0.1", 22); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if err := w.record("10.0.0.1", 80); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	done, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("loadCheckpoint: %v", err)
+	}
+	for _, key := range []string{checkpointKey("10.0.0.1", 22), checkpointKey("10.0.0.1", 80)} {
+		if _, ok := done[key]; !ok {
+			t.Errorf("loadCheckpoint missing key %q", key)
+		}
+	}
+	if len(done) != 2 {
+		t.Errorf("loadCheckpoint returned %d keys, want 2", len(done))
+	}
+}
+
+func TestLoadCheckpointMissingFileIsNotAnError(t *testing.T) {
+	done, err := loadCheckpoint(filepath.Join(t.TempDir(), "does-not-exist.txt"))
+	if err != nil {
+		t.Fatalf("loadCheckpoint: %v", err)
+	}
+	if len(done) != 0 {
+		t.Errorf("loadCheckpoint returned %d keys for a missing file, want 0", len(done))
+	}
+}
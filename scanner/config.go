@@ -0,0 +1,242 @@
+// port-scanner/scanner/config.go
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Options holds every tunable a Scanner accepts, so it can be built up in
+// layers: built-in defaults, then SCANNER_* env vars, then an --config INI
+// file (optionally a named [profile "..."] section within it), then
+// whatever a caller sets explicitly last, which wins.
+type Options struct {
+	Host           string
+	Targets        string
+	Ports          string
+	Concurrency    int
+	TimeoutMS      int
+	BannerBytes    int
+	OutFile        string
+	JSONL          bool
+	Resume         bool
+	CheckpointFile string
+	Probes         string
+	ScanType       string
+	Rate           float64
+	PerHostRate    float64
+	Adaptive       bool
+
+	// AllowFileTargets permits Targets/Host to contain an @file.txt
+	// reference, which Run reads off disk and turns into target hosts. The
+	// CLI sets this explicitly, since it's the only trusted caller; any
+	// Options built from a network request (e.g. scannerd's HTTP API) must
+	// leave it false.
+	AllowFileTargets bool
+}
+
+// DefaultOptions returns the built-in defaults a Scanner falls back to
+// before SCANNER_* env vars, an --config file, or explicit overrides are
+// layered on top.
+func DefaultOptions() Options {
+	return Options{
+		Ports:          "1-1024",
+		Concurrency:    200,
+		TimeoutMS:      300,
+		BannerBytes:    128,
+		OutFile:        "scan_results.json",
+		CheckpointFile: "scan_checkpoint.txt",
+		ScanType:       "connect",
+	}
+}
+
+// ApplyEnv overlays SCANNER_* environment variables onto opts.
+func ApplyEnv(opts *Options) {
+	env := func(name string) (string, bool) { return os.LookupEnv("SCANNER_" + name) }
+	if v, ok := env("HOST"); ok {
+		opts.Host = v
+	}
+	if v, ok := env("TARGETS"); ok {
+		opts.Targets = v
+	}
+	if v, ok := env("PORTS"); ok {
+		opts.Ports = v
+	}
+	if v, ok := env("CONCURRENCY"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			opts.Concurrency = n
+		}
+	}
+	if v, ok := env("TIMEOUT_MS"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			opts.TimeoutMS = n
+		}
+	}
+	if v, ok := env("BANNER_BYTES"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			opts.BannerBytes = n
+		}
+	}
+	if v, ok := env("OUTPUT"); ok {
+		opts.OutFile = v
+	}
+	if v, ok := env("JSONL"); ok {
+		opts.JSONL = isTruthy(v)
+	}
+	if v, ok := env("RESUME"); ok {
+		opts.Resume = isTruthy(v)
+	}
+	if v, ok := env("CHECKPOINT"); ok {
+		opts.CheckpointFile = v
+	}
+	if v, ok := env("PROBES"); ok {
+		opts.Probes = v
+	}
+	if v, ok := env("SCAN_TYPE"); ok {
+		opts.ScanType = v
+	}
+	if v, ok := env("RATE"); ok {
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			opts.Rate = n
+		}
+	}
+	if v, ok := env("PER_HOST_RATE"); ok {
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			opts.PerHostRate = n
+		}
+	}
+	if v, ok := env("ADAPTIVE"); ok {
+		opts.Adaptive = isTruthy(v)
+	}
+}
+
+func isTruthy(s string) bool {
+	b, err := strconv.ParseBool(s)
+	return err == nil && b
+}
+
+// INIFile is a parsed --config file: a flat set of global key/values, plus
+// any number of named [profile "name"] sections.
+type INIFile struct {
+	global   map[string]string
+	profiles map[string]map[string]string
+}
+
+// LoadINIFile parses a minimal INI dialect: "[section]" and
+// '[profile "name"]' headers, "key = value" pairs, and '#'/';' comments.
+func LoadINIFile(path string) (*INIFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	defer f.Close()
+
+	ini := &INIFile{global: map[string]string{}, profiles: map[string]map[string]string{}}
+	current := ini.global
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			header := strings.TrimSpace(line[1 : len(line)-1])
+			if name, ok := profileSectionName(header); ok {
+				profile, ok := ini.profiles[name]
+				if !ok {
+					profile = map[string]string{}
+					ini.profiles[name] = profile
+				}
+				current = profile
+			} else {
+				current = ini.global
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		current[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return ini, sc.Err()
+}
+
+// profileSectionName parses a `profile "name"` section header.
+func profileSectionName(header string) (string, bool) {
+	rest, ok := strings.CutPrefix(header, "profile")
+	if !ok {
+		return "", false
+	}
+	rest = strings.TrimSpace(rest)
+	if len(rest) < 2 || rest[0] != '"' || rest[len(rest)-1] != '"' {
+		return "", false
+	}
+	return rest[1 : len(rest)-1], true
+}
+
+// ApplyGlobal overlays the file's top-level keys onto opts.
+func (ini *INIFile) ApplyGlobal(opts *Options) {
+	applySection(ini.global, opts)
+}
+
+// ApplyProfile overlays a named [profile "name"] section onto opts.
+func (ini *INIFile) ApplyProfile(name string, opts *Options) error {
+	section, ok := ini.profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q not found in config file", name)
+	}
+	applySection(section, opts)
+	return nil
+}
+
+func applySection(section map[string]string, opts *Options) {
+	for key, value := range section {
+		switch key {
+		case "host":
+			opts.Host = value
+		case "targets":
+			opts.Targets = value
+		case "ports":
+			opts.Ports = value
+		case "concurrency":
+			if n, err := strconv.Atoi(value); err == nil {
+				opts.Concurrency = n
+			}
+		case "timeout", "timeout_ms":
+			if n, err := strconv.Atoi(value); err == nil {
+				opts.TimeoutMS = n
+			}
+		case "banner_bytes":
+			if n, err := strconv.Atoi(value); err == nil {
+				opts.BannerBytes = n
+			}
+		case "output":
+			opts.OutFile = value
+		case "jsonl":
+			opts.JSONL = isTruthy(value)
+		case "resume":
+			opts.Resume = isTruthy(value)
+		case "checkpoint":
+			opts.CheckpointFile = value
+		case "probes":
+			opts.Probes = value
+		case "scan_type":
+			opts.ScanType = value
+		case "rate":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				opts.Rate = n
+			}
+		case "per_host_rate":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				opts.PerHostRate = n
+			}
+		case "adaptive":
+			opts.Adaptive = isTruthy(value)
+		}
+	}
+}
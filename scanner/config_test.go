@@ -0,0 +1,158 @@
+// port-scanner/scanner/config_test.go
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyEnv(t *testing.T) {
+	for _, kv := range [][2]string{
+		{"SCANNER_HOST", "example.com"},
+		{"SCANNER_PORTS", "22,80"},
+		{"SCANNER_CONCURRENCY", "50"},
+		{"SCANNER_JSONL", "true"},
+		{"SCANNER_RATE", "12.5"},
+		{"SCANNER_ADAPTIVE", "1"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+
+	opts := DefaultOptions()
+	ApplyEnv(&opts)
+
+	if opts.Host != "example.com" {
+		t.Errorf("Host = %q, want %q", opts.Host, "example.com")
+	}
+	if opts.Ports != "22,80" {
+		t.Errorf("Ports = %q, want %q", opts.Ports, "22,80")
+	}
+	if opts.Concurrency != 50 {
+		t.Errorf("Concurrency = %d, want 50", opts.Concurrency)
+	}
+	if !opts.JSONL {
+		t.Error("JSONL = false, want true")
+	}
+	if opts.Rate != 12.5 {
+		t.Errorf("Rate = %v, want 12.5", opts.Rate)
+	}
+	if !opts.Adaptive {
+		t.Error("Adaptive = false, want true")
+	}
+}
+
+func TestApplyEnvIgnoresUnsetAndInvalid(t *testing.T) {
+	t.Setenv("SCANNER_CONCURRENCY", "not-a-number")
+
+	opts := DefaultOptions()
+	want := opts.Concurrency
+	ApplyEnv(&opts)
+
+	if opts.Concurrency != want {
+		t.Errorf("Concurrency = %d, want unchanged default %d for an unparsable value", opts.Concurrency, want)
+	}
+	if opts.Host != "" {
+		t.Errorf("Host = %q, want empty when SCANNER_HOST is unset", opts.Host)
+	}
+}
+
+func TestIsTruthy(t *testing.T) {
+	cases := map[string]bool{
+		"true": true, "1": true, "TRUE": true,
+		"false": false, "0": false, "": false, "yes": false,
+	}
+	for in, want := range cases {
+		if got := isTruthy(in); got != want {
+			t.Errorf("isTruthy(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func writeTempINI(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.ini")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing temp config: %v", err)
+	}
+	return path
+}
+
+func TestLoadINIFileGlobalAndProfile(t *testing.T) {
+	path := writeTempINI(t, `
+# a comment
+host = example.com
+ports = 80,443
+; another comment
+
+[profile "fast"]
+concurrency = 500
+adaptive = true
+
+[profile "quiet"]
+rate = 5
+`)
+	ini, err := LoadINIFile(path)
+	if err != nil {
+		t.Fatalf("LoadINIFile: %v", err)
+	}
+
+	opts := DefaultOptions()
+	ini.ApplyGlobal(&opts)
+	if opts.Host != "example.com" || opts.Ports != "80,443" {
+		t.Errorf("global section applied = %+v", opts)
+	}
+
+	if err := ini.ApplyProfile("fast", &opts); err != nil {
+		t.Fatalf("ApplyProfile(fast): %v", err)
+	}
+	if opts.Concurrency != 500 || !opts.Adaptive {
+		t.Errorf("profile %q applied = %+v", "fast", opts)
+	}
+
+	if err := ini.ApplyProfile("missing", &opts); err == nil {
+		t.Error("ApplyProfile should error for a profile not in the file")
+	}
+}
+
+func TestLoadINIFileMissingFile(t *testing.T) {
+	if _, err := LoadINIFile(filepath.Join(t.TempDir(), "nope.ini")); err == nil {
+		t.Error("LoadINIFile should error when the file doesn't exist")
+	}
+}
+
+func TestProfileSectionName(t *testing.T) {
+	cases := []struct {
+		header   string
+		wantName string
+		wantOK   bool
+	}{
+		{`profile "fast"`, "fast", true},
+		{`profile"fast"`, "fast", true},
+		{"section", "", false},
+		{`profile fast`, "", false},
+		{`profile ""`, "", true},
+	}
+	for _, c := range cases {
+		name, ok := profileSectionName(c.header)
+		if ok != c.wantOK || name != c.wantName {
+			t.Errorf("profileSectionName(%q) = (%q, %v), want (%q, %v)", c.header, name, ok, c.wantName, c.wantOK)
+		}
+	}
+}
+
+func TestApplySectionQuotedValueAndUnknownKeyIgnored(t *testing.T) {
+	path := writeTempINI(t, `
+checkpoint = "my checkpoint.txt"
+unknown_key = whatever
+`)
+	ini, err := LoadINIFile(path)
+	if err != nil {
+		t.Fatalf("LoadINIFile: %v", err)
+	}
+	opts := DefaultOptions()
+	ini.ApplyGlobal(&opts)
+	if opts.CheckpointFile != "my checkpoint.txt" {
+		t.Errorf("CheckpointFile = %q, want quotes stripped", opts.CheckpointFile)
+	}
+}
@@ -0,0 +1,40 @@
+// port-scanner/scanner/net_util.go
+package scanner
+
+import (
+	"fmt"
+	"net"
+)
+
+// resolveIPv4 resolves host to an IPv4 address. Raw-socket scan modes need
+// a concrete address up front to build packet headers.
+func resolveIPv4(host string) (net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		if v4 := ip.To4(); v4 != nil {
+			return v4, nil
+		}
+		return nil, fmt.Errorf("only IPv4 targets are supported for raw-socket scans: %s", host)
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range ips {
+		if v4 := candidate.To4(); v4 != nil {
+			return v4, nil
+		}
+	}
+	return nil, fmt.Errorf("no IPv4 address found for %s", host)
+}
+
+// outboundIPv4 discovers the local IPv4 address the kernel would pick to
+// reach dst, which raw TCP packets need for their pseudo-header checksum.
+func outboundIPv4(dst net.IP) (net.IP, error) {
+	conn, err := net.Dial("udp4", net.JoinHostPort(dst.String(), "80"))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP.To4(), nil
+}
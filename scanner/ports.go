@@ -0,0 +1,52 @@
+// port-scanner/scanner/ports.go
+package scanner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// parsePorts expands an Options.Ports spec (e.g. "22,80,8000-8100") into a
+// deduplicated, sorted list of valid port numbers.
+func parsePorts(s string) ([]int, error) {
+	set := make(map[int]struct{})
+	parts := strings.Split(s, ",")
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if strings.Contains(p, "-") {
+			var lo, hi int
+			_, err := fmt.Sscanf(p, "%d-%d", &lo, &hi)
+			if err != nil {
+				return nil, fmt.Errorf("bad range: %s", p)
+			}
+			if lo < 1 {
+				lo = 1
+			}
+			if hi > 65535 {
+				hi = 65535
+			}
+			for i := lo; i <= hi; i++ {
+				set[i] = struct{}{}
+			}
+		} else {
+			var v int
+			_, err := fmt.Sscanf(p, "%d", &v)
+			if err != nil {
+				return nil, fmt.Errorf("bad port: %s", p)
+			}
+			if v >= 1 && v <= 65535 {
+				set[v] = struct{}{}
+			}
+		}
+	}
+	out := make([]int, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Ints(out)
+	return out, nil
+}
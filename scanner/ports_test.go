@@ -0,0 +1,41 @@
+// port-scanner/scanner/ports_test.go
+package scanner
+
+import "testing"
+
+func TestParsePorts(t *testing.T) {
+	cases := []struct {
+		spec string
+		want []int
+	}{
+		{"22,80,443", []int{22, 80, 443}},
+		{"1-5", []int{1, 2, 3, 4, 5}},
+		{"22,80,8000-8002", []int{22, 80, 8000, 8001, 8002}},
+		{"80,80,22", []int{22, 80}},                    // duplicates collapse and the result is sorted
+		{" 22 , 80 ", []int{22, 80}},                   // surrounding whitespace is trimmed
+		{"0-2,65534-70000", []int{1, 2, 65534, 65535}}, // out-of-range ends of a range are clamped
+	}
+
+	for _, c := range cases {
+		got, err := parsePorts(c.spec)
+		if err != nil {
+			t.Fatalf("parsePorts(%q): %v", c.spec, err)
+		}
+		if len(got) != len(c.want) {
+			t.Fatalf("parsePorts(%q) = %v, want %v", c.spec, got, c.want)
+		}
+		for i := range c.want {
+			if got[i] != c.want[i] {
+				t.Errorf("parsePorts(%q)[%d] = %d, want %d", c.spec, i, got[i], c.want[i])
+			}
+		}
+	}
+}
+
+func TestParsePortsRejectsGarbage(t *testing.T) {
+	for _, spec := range []string{"abc", "1-", "-5"} {
+		if _, err := parsePorts(spec); err == nil {
+			t.Errorf("parsePorts(%q): expected error, got none", spec)
+		}
+	}
+}
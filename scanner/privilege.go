@@ -0,0 +1,53 @@
+// port-scanner/scanner/privilege.go
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// capNetRawBit is CAP_NET_RAW's bit position in the Linux capability sets
+// reported by /proc/self/status (see capability.h).
+const capNetRawBit = 13
+
+// checkRawSocketPrivilege returns a clear error if the process can't open
+// raw sockets, which --scan-type=syn and --scan-type=udp both require.
+func checkRawSocketPrivilege() error {
+	if os.Geteuid() == 0 {
+		return nil
+	}
+	if hasCapNetRaw() {
+		return nil
+	}
+	return fmt.Errorf("raw-socket scan modes require root or CAP_NET_RAW (run as root, or: sudo setcap cap_net_raw+ep <binary>)")
+}
+
+// hasCapNetRaw parses the effective capability bitmask from
+// /proc/self/status. It returns false (rather than erroring) on platforms
+// or sandboxes where that file isn't available — the root check above is
+// the common case there.
+func hasCapNetRaw() bool {
+	f, err := os.Open("/proc/self/status")
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "CapEff:") {
+			continue
+		}
+		hex := strings.TrimSpace(strings.TrimPrefix(line, "CapEff:"))
+		mask, err := strconv.ParseUint(hex, 16, 64)
+		if err != nil {
+			return false
+		}
+		return mask&(1<<capNetRawBit) != 0
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// port-scanner/scanner/probe.go
+package scanner
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// Prober performs an active, protocol-specific handshake against an already
+// open connection and returns structured fingerprint details.
+type Prober interface {
+	Name() string
+	Probe(ctx context.Context, conn net.Conn, host string, port int) (map[string]any, error)
+}
+
+// defaultPortProbers maps well-known ports to the prober that should run
+// against them, mirroring common service/port conventions.
+var defaultPortProbers = map[int]string{
+	80:   "http",
+	8000: "http",
+	8080: "http",
+	443:  "tls",
+	8443: "tls",
+	22:   "ssh",
+	6379: "redis",
+	139:  "smb",
+	445:  "smb",
+}
+
+// proberRegistry holds the built-in probers, keyed by name.
+type proberRegistry struct {
+	probers map[string]Prober
+}
+
+func newProberRegistry() *proberRegistry {
+	r := &proberRegistry{probers: make(map[string]Prober)}
+	for _, p := range []Prober{httpProber{}, tlsProber{}, sshProber{}, redisProber{}, smbProber{}} {
+		r.probers[p.Name()] = p
+	}
+	return r
+}
+
+// selected parses a --probes=http,tls,... flag value into the subset of
+// registered probers to run. An empty spec enables nothing (passive banner
+// grabbing only).
+func (r *proberRegistry) selected(spec string) map[string]Prober {
+	if strings.TrimSpace(spec) == "" {
+		return nil
+	}
+	sel := make(map[string]Prober)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if p, ok := r.probers[name]; ok {
+			sel[name] = p
+		}
+	}
+	return sel
+}
+
+// forPort returns the enabled prober for a port, or nil if none applies.
+func (r *proberRegistry) forPort(port int, sel map[string]Prober) Prober {
+	if sel == nil {
+		return nil
+	}
+	name, ok := defaultPortProbers[port]
+	if !ok {
+		return nil
+	}
+	return sel[name]
+}
@@ -0,0 +1,70 @@
+// port-scanner/scanner/probe_http.go
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+type httpProber struct{}
+
+func (httpProber) Name() string { return "http" }
+
+// Probe sends a minimal HTTP/1.0 request and captures the status line, the
+// Server header, and the document title if present.
+func (httpProber) Probe(ctx context.Context, conn net.Conn, host string, port int) (map[string]any, error) {
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	req := fmt.Sprintf("GET / HTTP/1.0\r\nHost: %s\r\n\r\n", host)
+	if _, err := conn.Write([]byte(req)); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	statusLine, err := reader.ReadString('\n')
+	if err != nil && statusLine == "" {
+		return nil, err
+	}
+	details := map[string]any{"status_line": strings.TrimSpace(statusLine)}
+
+	for {
+		line, err := reader.ReadString('\n')
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			break
+		}
+		if k, v, ok := strings.Cut(trimmed, ":"); ok && strings.EqualFold(strings.TrimSpace(k), "server") {
+			details["server"] = strings.TrimSpace(v)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	body := make([]byte, 4096)
+	if n, _ := reader.Read(body); n > 0 {
+		if title := extractTitle(string(body[:n])); title != "" {
+			details["title"] = title
+		}
+	}
+
+	return details, nil
+}
+
+func extractTitle(body string) string {
+	lower := strings.ToLower(body)
+	start := strings.Index(lower, "<title>")
+	if start == -1 {
+		return ""
+	}
+	start += len("<title>")
+	end := strings.Index(lower[start:], "</title>")
+	if end == -1 {
+		return ""
+	}
+	return strings.TrimSpace(body[start : start+end])
+}
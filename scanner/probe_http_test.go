@@ -0,0 +1,22 @@
+// port-scanner/scanner/probe_http_test.go
+package scanner
+
+import "testing"
+
+func TestExtractTitle(t *testing.T) {
+	cases := []struct {
+		body string
+		want string
+	}{
+		{"<html><head><title>Example Page</title></head></html>", "Example Page"},
+		{"<HTML><TITLE>Upper Case</TITLE></html>", "Upper Case"},
+		{"<title>  padded  </title>", "padded"},
+		{"<html><body>no title here</body></html>", ""},
+		{"<title>unterminated", ""},
+	}
+	for _, c := range cases {
+		if got := extractTitle(c.body); got != c.want {
+			t.Errorf("extractTitle(%q) = %q, want %q", c.body, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// port-scanner/scanner/probe_redis.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+type redisProber struct{}
+
+func (redisProber) Name() string { return "redis" }
+
+// Probe sends PING and expects the +PONG simple-string reply from the
+// Redis protocol (RESP).
+func (redisProber) Probe(ctx context.Context, conn net.Conn, host string, port int) (map[string]any, error) {
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	if _, err := conn.Write([]byte("PING\r\n")); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	resp := strings.TrimSpace(string(buf[:n]))
+	if resp != "+PONG" {
+		return nil, fmt.Errorf("unexpected response: %q", resp)
+	}
+	return map[string]any{"ping": resp}, nil
+}
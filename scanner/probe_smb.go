@@ -0,0 +1,98 @@
+// port-scanner/scanner/probe_smb.go
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+type smbProber struct{}
+
+func (smbProber) Name() string { return "smb" }
+
+// smbDialects are the dialect strings offered in the negotiate request, in
+// the order an SMB1 server indexes its DialectIndex response field against.
+var smbDialects = []string{
+	"PC NETWORK PROGRAM 1.0",
+	"LANMAN1.0",
+	"Windows for Workgroups 3.1a",
+	"LM1.2X002",
+	"LANMAN2.1",
+	"NT LM 0.12",
+	"SMB 2.002",
+	"SMB 2.???",
+}
+
+// Probe sends a minimal SMB1 Negotiate Protocol Request listing the
+// dialects above and reports which one the server selected. This is a
+// best-effort fingerprint: it is enough to tell SMB1/2-capable hosts apart
+// from each other, not a full protocol implementation.
+func (smbProber) Probe(ctx context.Context, conn net.Conn, host string, port int) (map[string]any, error) {
+	conn.SetDeadline(time.Now().Add(3 * time.Second))
+
+	if _, err := conn.Write(buildSMBNegotiateRequest()); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	length := int(header[1])<<16 | int(header[2])<<8 | int(header[3])
+	if length <= 0 || length > 4096 {
+		return nil, fmt.Errorf("unexpected SMB response length: %d", length)
+	}
+	resp := make([]byte, length)
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return nil, err
+	}
+	if len(resp) < 4 || resp[0] != 0xFF || resp[1] != 'S' || resp[2] != 'M' || resp[3] != 'B' {
+		return nil, fmt.Errorf("not an SMB response")
+	}
+
+	details := map[string]any{"response_length": length}
+	// In an SMB1 negotiate response, WordCount sits at offset 32 and, when
+	// 1, is followed by a 2-byte little-endian DialectIndex.
+	if len(resp) >= 35 && resp[32] >= 1 {
+		idx := int(resp[33]) | int(resp[34])<<8
+		if idx >= 0 && idx < len(smbDialects) {
+			details["dialect"] = smbDialects[idx]
+		} else {
+			details["dialect_index"] = idx
+		}
+	}
+	return details, nil
+}
+
+func buildSMBNegotiateRequest() []byte {
+	var body bytes.Buffer
+	body.Write([]byte{0xFF, 'S', 'M', 'B'}) // protocol signature
+	body.WriteByte(0x72)                    // command: Negotiate Protocol
+	body.Write(make([]byte, 4))             // NT status
+	body.WriteByte(0x18)                    // flags
+	body.Write([]byte{0x53, 0xC8})          // flags2: unicode, long names, NT error codes
+	body.Write(make([]byte, 12))            // PIDHigh + security signature + reserved
+	body.Write(make([]byte, 2))             // TID
+	body.Write([]byte{0xFF, 0xFE})          // PIDLow placeholder
+	body.Write(make([]byte, 4))             // UID + MID
+	body.WriteByte(0x00)                    // WordCount
+
+	var dialects bytes.Buffer
+	for _, d := range smbDialects {
+		dialects.WriteByte(0x02) // dialect buffer format
+		dialects.WriteString(d)
+		dialects.WriteByte(0x00)
+	}
+	byteCount := dialects.Len()
+	body.WriteByte(byte(byteCount))
+	body.WriteByte(byte(byteCount >> 8))
+	body.Write(dialects.Bytes())
+
+	length := body.Len()
+	nbss := []byte{0x00, byte(length >> 16), byte(length >> 8), byte(length)}
+	return append(nbss, body.Bytes()...)
+}
@@ -0,0 +1,30 @@
+// port-scanner/scanner/probe_smb_test.go
+package scanner
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBuildSMBNegotiateRequest(t *testing.T) {
+	req := buildSMBNegotiateRequest()
+
+	if len(req) < 4 {
+		t.Fatalf("request too short: %d bytes", len(req))
+	}
+	nbssLen := int(req[1])<<16 | int(req[2])<<8 | int(req[3])
+	if got := len(req) - 4; got != nbssLen {
+		t.Errorf("NBSS length header = %d, want %d (actual body length)", nbssLen, got)
+	}
+
+	body := req[4:]
+	if !bytes.HasPrefix(body, []byte{0xFF, 'S', 'M', 'B', 0x72}) {
+		t.Errorf("body does not start with the SMB1 Negotiate Protocol signature+command: %x", body[:5])
+	}
+
+	for _, dialect := range smbDialects {
+		if !bytes.Contains(body, []byte(dialect)) {
+			t.Errorf("request body missing dialect %q", dialect)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// port-scanner/scanner/probe_ssh.go
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+type sshProber struct{}
+
+func (sshProber) Name() string { return "ssh" }
+
+// Probe reads the server's SSH identification string (RFC 4253 §4.2),
+// which is sent unprompted as soon as the TCP connection is established.
+func (sshProber) Probe(ctx context.Context, conn net.Conn, host string, port int) (map[string]any, error) {
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return nil, err
+	}
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "SSH-") {
+		return nil, fmt.Errorf("not an SSH identification string: %q", line)
+	}
+
+	details := map[string]any{"identification": line}
+	if parts := strings.SplitN(line, "-", 3); len(parts) == 3 {
+		details["protocol_version"] = parts[1]
+		details["software_version"] = parts[2]
+	}
+	return details, nil
+}
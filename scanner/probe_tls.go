@@ -0,0 +1,62 @@
+// port-scanner/scanner/probe_tls.go
+package scanner
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+)
+
+type tlsProber struct{}
+
+func (tlsProber) Name() string { return "tls" }
+
+// Probe performs a TLS handshake with certificate verification disabled so
+// it can fingerprint expired or self-signed endpoints too, and records the
+// negotiated version, cipher, and leaf certificate details.
+func (tlsProber) Probe(ctx context.Context, conn net.Conn, host string, port int) (map[string]any, error) {
+	conn.SetDeadline(time.Now().Add(3 * time.Second))
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		InsecureSkipVerify: true,
+		ServerName:         host,
+	})
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, err
+	}
+
+	state := tlsConn.ConnectionState()
+	details := map[string]any{
+		"version":      tlsVersionName(state.Version),
+		"cipher_suite": tls.CipherSuiteName(state.CipherSuite),
+	}
+
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		details["subject"] = cert.Subject.String()
+		details["issuer"] = cert.Issuer.String()
+		details["not_after"] = cert.NotAfter.Format(time.RFC3339)
+		if len(cert.DNSNames) > 0 {
+			details["sans"] = cert.DNSNames
+		}
+	}
+
+	return details, nil
+}
+
+func tlsVersionName(v uint16) string {
+	switch v {
+	case tls.VersionTLS10:
+		return "TLS1.0"
+	case tls.VersionTLS11:
+		return "TLS1.1"
+	case tls.VersionTLS12:
+		return "TLS1.2"
+	case tls.VersionTLS13:
+		return "TLS1.3"
+	default:
+		return fmt.Sprintf("0x%04x", v)
+	}
+}
@@ -0,0 +1,25 @@
+// port-scanner/scanner/probe_tls_test.go
+package scanner
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func TestTLSVersionName(t *testing.T) {
+	cases := []struct {
+		version uint16
+		want    string
+	}{
+		{tls.VersionTLS10, "TLS1.0"},
+		{tls.VersionTLS11, "TLS1.1"},
+		{tls.VersionTLS12, "TLS1.2"},
+		{tls.VersionTLS13, "TLS1.3"},
+		{0x0042, "0x0042"},
+	}
+	for _, c := range cases {
+		if got := tlsVersionName(c.version); got != c.want {
+			t.Errorf("tlsVersionName(0x%04x) = %q, want %q", c.version, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// port-scanner/scanner/ratelimit.go
+package scanner
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	adaptiveWindowSize   = 20  // dials sampled before an adaptive adjustment
+	adaptiveTimeoutRatio = 0.2 // timeout share in the window that triggers a cut
+	adaptiveMinRate      = 1.0 // floor so a host never gets throttled to zero
+)
+
+// rateController throttles dials against a global token bucket and, when
+// --per-host-rate is set, a bucket per host. In --adaptive mode, each
+// host's bucket is retuned AIMD-style from a rolling window of recent dial
+// outcomes: too many timeouts halves the rate, a clean window nudges it
+// back up.
+type rateController struct {
+	global      *rate.Limiter
+	perHostRate float64 // 0 disables per-host limiting
+	adaptive    bool
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+type hostLimiter struct {
+	limiter *rate.Limiter
+	window  []bool // recent dial-timeout outcomes, oldest first
+}
+
+func newRateController(globalRate, perHostRate float64, adaptive bool) *rateController {
+	rc := &rateController{perHostRate: perHostRate, adaptive: adaptive, hosts: make(map[string]*hostLimiter)}
+	if globalRate > 0 {
+		rc.global = rate.NewLimiter(rate.Limit(globalRate), max(1, int(globalRate)))
+	}
+	return rc
+}
+
+// wait blocks until both the global and per-host buckets (if configured)
+// allow the next dial against host.
+func (rc *rateController) wait(ctx context.Context, host string) error {
+	if rc.global != nil {
+		if err := rc.global.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if hl := rc.hostLimiter(host); hl != nil {
+		if err := hl.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rc *rateController) hostLimiter(host string) *hostLimiter {
+	if rc.perHostRate <= 0 {
+		return nil
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	hl, ok := rc.hosts[host]
+	if !ok {
+		hl = &hostLimiter{limiter: rate.NewLimiter(rate.Limit(rc.perHostRate), max(1, int(rc.perHostRate)))}
+		rc.hosts[host] = hl
+	}
+	return hl
+}
+
+// record feeds a dial outcome into the adaptive controller. It reports
+// whether this sample completed a window and triggered a rate change, and
+// the new effective rate, so the caller can surface it to the user.
+func (rc *rateController) record(host string, timedOut bool) (adjusted bool, newRate float64) {
+	if !rc.adaptive || rc.perHostRate <= 0 {
+		return false, 0
+	}
+	hl := rc.hostLimiter(host)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	hl.window = append(hl.window, timedOut)
+	if len(hl.window) < adaptiveWindowSize {
+		return false, 0
+	}
+	timeouts := 0
+	for _, t := range hl.window {
+		if t {
+			timeouts++
+		}
+	}
+	hl.window = hl.window[:0]
+
+	current := float64(hl.limiter.Limit())
+	ratio := float64(timeouts) / float64(adaptiveWindowSize)
+	switch {
+	case ratio > adaptiveTimeoutRatio:
+		current = max(adaptiveMinRate, current/2)
+	case timeouts == 0:
+		current = min(rc.perHostRate, current+current*0.1)
+	default:
+		return false, 0
+	}
+
+	hl.limiter.SetLimit(rate.Limit(current))
+	hl.limiter.SetBurst(max(1, int(current)))
+	return true, current
+}
+
+// isDialTimeout reports whether err represents a dial timing out, as
+// opposed to e.g. a connection refused.
+func isDialTimeout(err error) bool {
+	ne, ok := err.(net.Error)
+	return ok && ne.Timeout()
+}
@@ -0,0 +1,100 @@
+// port-scanner/scanner/ratelimit_test.go
+package scanner
+
+import "testing"
+
+// fillWindow feeds adaptiveWindowSize samples into rc for host, with the
+// given number of them marked as timed out, and returns record's result for
+// the final (window-completing) sample.
+func fillWindow(rc *rateController, host string, timeouts int) (bool, float64) {
+	var adjusted bool
+	var newRate float64
+	for i := 0; i < adaptiveWindowSize; i++ {
+		adjusted, newRate = rc.record(host, i < timeouts)
+	}
+	return adjusted, newRate
+}
+
+func TestRateControllerRecordIncreasesOnCleanWindow(t *testing.T) {
+	rc := newRateController(0, 10, true)
+	rc.hostLimiter("h")
+
+	// Halve it first so there's room to grow below the per-host ceiling.
+	_, halved := fillWindow(rc, "h", 20)
+	if halved != 5 {
+		t.Fatalf("setup: newRate after halving = %v, want 5", halved)
+	}
+
+	adjusted, newRate := fillWindow(rc, "h", 0)
+	if !adjusted {
+		t.Fatal("expected a clean window to trigger an adjustment")
+	}
+	if newRate <= halved {
+		t.Errorf("newRate = %v, want an increase above %v", newRate, halved)
+	}
+}
+
+func TestRateControllerRecordHalvesOnManyTimeouts(t *testing.T) {
+	rc := newRateController(0, 10, true)
+	rc.hostLimiter("h")
+
+	// adaptiveTimeoutRatio is 0.2, so 5+ timeouts out of 20 should trigger a cut.
+	adjusted, newRate := fillWindow(rc, "h", 5)
+	if !adjusted {
+		t.Fatal("expected a window over the timeout ratio to trigger an adjustment")
+	}
+	if newRate != 5 {
+		t.Errorf("newRate = %v, want 5 (halved from 10)", newRate)
+	}
+}
+
+func TestRateControllerRecordNoChangeBelowThreshold(t *testing.T) {
+	rc := newRateController(0, 10, true)
+	rc.hostLimiter("h")
+
+	// 20% of 20 is 4, which is not > adaptiveTimeoutRatio's threshold and
+	// isn't zero either, so record should report no adjustment.
+	adjusted, _ := fillWindow(rc, "h", 4)
+	if adjusted {
+		t.Error("expected no adjustment for a window at exactly the timeout ratio")
+	}
+}
+
+func TestRateControllerRecordRespectsFloor(t *testing.T) {
+	rc := newRateController(0, 1, true)
+	rc.hostLimiter("h")
+
+	_, newRate := fillWindow(rc, "h", 20)
+	if newRate != adaptiveMinRate {
+		t.Errorf("newRate = %v, want the floor of %v", newRate, adaptiveMinRate)
+	}
+}
+
+func TestRateControllerRecordRespectsCeiling(t *testing.T) {
+	rc := newRateController(0, 10, true)
+	rc.hostLimiter("h")
+
+	_, newRate := fillWindow(rc, "h", 0)
+	if newRate > 10 {
+		t.Errorf("newRate = %v, want it capped at the configured per-host rate of 10", newRate)
+	}
+}
+
+func TestRateControllerRecordDisabledWithoutAdaptive(t *testing.T) {
+	rc := newRateController(0, 10, false)
+	rc.hostLimiter("h")
+
+	adjusted, _ := fillWindow(rc, "h", 0)
+	if adjusted {
+		t.Error("record should never adjust when adaptive mode is off")
+	}
+}
+
+func TestRateControllerRecordDisabledWithoutPerHostRate(t *testing.T) {
+	rc := newRateController(0, 0, true)
+
+	adjusted, _ := fillWindow(rc, "h", 0)
+	if adjusted {
+		t.Error("record should never adjust when per-host rate limiting is disabled")
+	}
+}
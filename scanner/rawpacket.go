@@ -0,0 +1,85 @@
+// port-scanner/scanner/rawpacket.go
+package scanner
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+// tcpHeaderLen is the length of a TCP header with no options.
+const tcpHeaderLen = 20
+
+type tcpFlags uint8
+
+const (
+	tcpFlagFIN tcpFlags = 1 << 0
+	tcpFlagSYN tcpFlags = 1 << 1
+	tcpFlagRST tcpFlags = 1 << 2
+	tcpFlagACK tcpFlags = 1 << 4
+)
+
+// buildTCPSYN builds a minimal (no-options) TCP SYN segment. srcIP/dstIP
+// are only used for the pseudo-header checksum, not written into the IP
+// header — the kernel fills that in for a non-IP_HDRINCL raw socket.
+func buildTCPSYN(srcIP, dstIP net.IP, srcPort, dstPort uint16, seq uint32) []byte {
+	buf := make([]byte, tcpHeaderLen)
+	binary.BigEndian.PutUint16(buf[0:2], srcPort)
+	binary.BigEndian.PutUint16(buf[2:4], dstPort)
+	binary.BigEndian.PutUint32(buf[4:8], seq)
+	binary.BigEndian.PutUint32(buf[8:12], 0) // ack number
+	buf[12] = 5 << 4                         // data offset: 5 words, no options
+	buf[13] = byte(tcpFlagSYN)
+	binary.BigEndian.PutUint16(buf[14:16], 64240) // window
+	binary.BigEndian.PutUint16(buf[16:18], 0)      // checksum, filled below
+	binary.BigEndian.PutUint16(buf[18:20], 0)      // urgent pointer
+
+	binary.BigEndian.PutUint16(buf[16:18], tcpChecksum(srcIP, dstIP, buf))
+	return buf
+}
+
+// tcpChecksum computes the TCP checksum over the IPv4 pseudo-header plus
+// segment, per RFC 793 §3.1.
+func tcpChecksum(srcIP, dstIP net.IP, segment []byte) uint16 {
+	pseudo := make([]byte, 12+len(segment))
+	copy(pseudo[0:4], srcIP.To4())
+	copy(pseudo[4:8], dstIP.To4())
+	pseudo[8] = 0
+	pseudo[9] = 6 // protocol: TCP
+	binary.BigEndian.PutUint16(pseudo[10:12], uint16(len(segment)))
+	copy(pseudo[12:], segment)
+	return checksum16(pseudo)
+}
+
+// checksum16 is the standard one's-complement Internet checksum used by
+// both TCP and ICMP.
+func checksum16(data []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(data); i += 2 {
+		sum += uint32(data[i])<<8 | uint32(data[i+1])
+	}
+	if len(data)%2 == 1 {
+		sum += uint32(data[len(data)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	return ^uint16(sum)
+}
+
+// parsedTCPHeader is the subset of a received TCP segment the SYN scanner
+// needs to classify a port.
+type parsedTCPHeader struct {
+	SrcPort, DstPort uint16
+	Flags            tcpFlags
+}
+
+func parseTCPHeader(b []byte) (parsedTCPHeader, bool) {
+	if len(b) < tcpHeaderLen {
+		return parsedTCPHeader{}, false
+	}
+	return parsedTCPHeader{
+		SrcPort: binary.BigEndian.Uint16(b[0:2]),
+		DstPort: binary.BigEndian.Uint16(b[2:4]),
+		Flags:   tcpFlags(b[13]),
+	}, true
+}
@@ -0,0 +1,79 @@
+// port-scanner/scanner/rawpacket_test.go
+package scanner
+
+import (
+	"net"
+	"testing"
+)
+
+func TestChecksum16KnownValue(t *testing.T) {
+	// RFC 1071 §3 worked example.
+	data := []byte{0x00, 0x01, 0xf2, 0x03, 0xf4, 0xf5, 0xf6, 0xf7}
+	if got, want := checksum16(data), uint16(0x220d); got != want {
+		t.Errorf("checksum16(%x) = 0x%04x, want 0x%04x", data, got, want)
+	}
+}
+
+func TestChecksum16OddLength(t *testing.T) {
+	// An odd-length buffer should be padded with a zero byte, not panic or
+	// silently drop the trailing byte.
+	data := []byte{0x00, 0x01, 0xf2}
+	if got := checksum16(data); got == 0 {
+		t.Errorf("checksum16(%x) = 0, want a nonzero checksum", data)
+	}
+}
+
+func TestBuildTCPSYNFields(t *testing.T) {
+	srcIP := net.ParseIP("10.0.0.1")
+	dstIP := net.ParseIP("10.0.0.2")
+	seg := buildTCPSYN(srcIP, dstIP, 40001, 443, 0x12345678)
+
+	if len(seg) != tcpHeaderLen {
+		t.Fatalf("len(seg) = %d, want %d", len(seg), tcpHeaderLen)
+	}
+	hdr, ok := parseTCPHeader(seg)
+	if !ok {
+		t.Fatalf("parseTCPHeader failed to parse a segment buildTCPSYN just built")
+	}
+	if hdr.SrcPort != 40001 {
+		t.Errorf("SrcPort = %d, want 40001", hdr.SrcPort)
+	}
+	if hdr.DstPort != 443 {
+		t.Errorf("DstPort = %d, want 443", hdr.DstPort)
+	}
+	if hdr.Flags != tcpFlagSYN {
+		t.Errorf("Flags = %x, want SYN only", hdr.Flags)
+	}
+
+	// A valid TCP checksum over the pseudo-header + segment sums to zero.
+	pseudo := make([]byte, 12+len(seg))
+	copy(pseudo[0:4], srcIP.To4())
+	copy(pseudo[4:8], dstIP.To4())
+	pseudo[9] = 6
+	pseudo[10], pseudo[11] = byte(len(seg)>>8), byte(len(seg))
+	copy(pseudo[12:], seg)
+	if sum := checksum16(pseudo); sum != 0 {
+		t.Errorf("checksum over pseudo-header + segment = 0x%04x, want 0", sum)
+	}
+}
+
+func TestParseTCPHeaderTooShort(t *testing.T) {
+	if _, ok := parseTCPHeader(make([]byte, tcpHeaderLen-1)); ok {
+		t.Error("parseTCPHeader should reject a buffer shorter than a TCP header")
+	}
+}
+
+func TestParseTCPHeaderFlags(t *testing.T) {
+	seg := make([]byte, tcpHeaderLen)
+	seg[13] = byte(tcpFlagSYN | tcpFlagACK)
+	hdr, ok := parseTCPHeader(seg)
+	if !ok {
+		t.Fatal("parseTCPHeader unexpectedly failed")
+	}
+	if hdr.Flags&tcpFlagSYN == 0 || hdr.Flags&tcpFlagACK == 0 {
+		t.Errorf("Flags = %x, want SYN|ACK set", hdr.Flags)
+	}
+	if hdr.Flags&tcpFlagRST != 0 {
+		t.Errorf("Flags = %x, want RST unset", hdr.Flags)
+	}
+}
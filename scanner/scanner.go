@@ -0,0 +1,283 @@
+// port-scanner/scanner/scanner.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScanResult is the outcome of probing a single (host, port) pair.
+type ScanResult struct {
+	Host     string         `json:"host"`
+	Port     int            `json:"port"`
+	State    string         `json:"state"`
+	Banner   string         `json:"banner,omitempty"`
+	Service  string         `json:"service,omitempty"`
+	Details  map[string]any `json:"details,omitempty"`
+	Duration string         `json:"duration_ms"`
+}
+
+const (
+	StateOpen         = "open"
+	StateClosed       = "closed"
+	StateFiltered     = "filtered"
+	StateOpenFiltered = "open-filtered"
+)
+
+// Job is a single (host, port) pair to dial, produced by the target
+// producer and consumed by the worker pool.
+type Job struct {
+	Host string
+	Port int
+}
+
+// Scanner runs a configured scan against a set of targets. Build one with
+// New and start it with Run; a Scanner is safe to Run only once.
+type Scanner struct {
+	opts     Options
+	registry *proberRegistry
+	probers  map[string]Prober
+	rc       *rateController
+}
+
+// New builds a Scanner from opts. It does no I/O and performs no
+// validation; invalid options (a bad scan type, missing targets, ...) are
+// reported by Run instead.
+func New(opts Options) *Scanner {
+	registry := newProberRegistry()
+	s := &Scanner{
+		opts:     opts,
+		registry: registry,
+		probers:  registry.selected(opts.Probes),
+	}
+	if opts.Rate > 0 || opts.PerHostRate > 0 {
+		s.rc = newRateController(opts.Rate, opts.PerHostRate, opts.Adaptive)
+	}
+	return s
+}
+
+// Run validates the Scanner's options, expands its targets and ports, and
+// starts the worker pool. It returns a channel that yields one ScanResult
+// per job and closes once every job has been dialed. Run returns
+// immediately, without starting any goroutines, if opts is invalid or a
+// required raw-socket privilege check fails.
+func (s *Scanner) Run(ctx context.Context) (<-chan ScanResult, error) {
+	opts := s.opts
+	if opts.ScanType != "connect" && opts.ScanType != "syn" && opts.ScanType != "udp" {
+		return nil, fmt.Errorf("invalid scan type %q: must be connect, syn, or udp", opts.ScanType)
+	}
+	if opts.Concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1, got %d", opts.Concurrency)
+	}
+	if opts.ScanType == "syn" || opts.ScanType == "udp" {
+		if err := checkRawSocketPrivilege(); err != nil {
+			return nil, err
+		}
+	}
+
+	spec := opts.Targets
+	if spec == "" {
+		spec = opts.Host
+	}
+	if spec == "" {
+		return nil, fmt.Errorf("host or targets required")
+	}
+	targets, err := ParseTargets(spec, opts.AllowFileTargets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid targets: %w", err)
+	}
+
+	portList, err := parsePorts(opts.Ports)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ports: %w", err)
+	}
+
+	var done map[string]struct{}
+	var ckpt *checkpointWriter
+	if opts.Resume {
+		done, err = loadCheckpoint(opts.CheckpointFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading checkpoint: %w", err)
+		}
+		ckpt, err = openCheckpointWriter(opts.CheckpointFile)
+		if err != nil {
+			return nil, fmt.Errorf("opening checkpoint: %w", err)
+		}
+	}
+
+	// For syn/udp, every worker shares a single raw socket: opening one per
+	// in-flight job would mean up to --concurrency raw sockets held open at
+	// once for no benefit, since a raw socket can already see replies for
+	// any probe regardless of which worker sent it.
+	var synSock *synSocket
+	var icmpSock *icmpSocket
+	switch opts.ScanType {
+	case "syn":
+		if synSock, err = newSynSocket(); err != nil {
+			return nil, err
+		}
+	case "udp":
+		if icmpSock, err = newICMPSocket(); err != nil {
+			return nil, err
+		}
+	}
+
+	jobs := make(chan Job, opts.Concurrency)
+	raw := make(chan ScanResult, opts.Concurrency)
+	out := make(chan ScanResult, opts.Concurrency)
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go worker(ctx, &wg, jobs, raw, opts.ScanType, time.Duration(opts.TimeoutMS)*time.Millisecond, opts.BannerBytes, s.registry, s.probers, s.rc, synSock, icmpSock)
+	}
+	go produceJobs(ctx, jobs, targets, portList, done)
+	go func() {
+		wg.Wait()
+		if synSock != nil {
+			synSock.Close()
+		}
+		if icmpSock != nil {
+			icmpSock.Close()
+		}
+		close(raw)
+	}()
+
+	// Record each result in the checkpoint before handing it to the caller,
+	// so a result the caller has seen is always safe to skip on --resume.
+	go func() {
+		defer close(out)
+		if ckpt != nil {
+			defer ckpt.Close()
+		}
+		for r := range raw {
+			if ckpt != nil {
+				if err := ckpt.record(r.Host, r.Port); err != nil {
+					fmt.Println("error writing checkpoint:", err)
+				}
+			}
+			out <- r
+		}
+	}()
+
+	return out, nil
+}
+
+func worker(ctx context.Context, wg *sync.WaitGroup, jobs <-chan Job, results chan<- ScanResult, scanType string, timeout time.Duration, bannerReadBytes int, registry *proberRegistry, probers map[string]Prober, rc *rateController, synSock *synSocket, icmpSock *icmpSocket) {
+	defer wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job, ok := <-jobs:
+			if !ok {
+				return
+			}
+			if rc != nil {
+				if err := rc.wait(ctx, job.Host); err != nil {
+					return
+				}
+			}
+
+			start := time.Now()
+			var res ScanResult
+			var timedOut bool
+			switch scanType {
+			case "syn":
+				res = scanSYN(ctx, synSock, job, timeout)
+				timedOut = res.State == StateFiltered
+			case "udp":
+				res = scanUDP(ctx, icmpSock, job, timeout)
+				timedOut = res.State == StateOpenFiltered
+			default:
+				res, timedOut = scanConnect(ctx, job, timeout, bannerReadBytes, registry, probers)
+			}
+			res.Duration = fmt.Sprintf("%d", time.Since(start).Milliseconds())
+
+			if rc != nil {
+				if adjusted, newRate := rc.record(job.Host, timedOut); adjusted {
+					fmt.Printf("[rate] %s throttled to %.2f/s\n", job.Host, newRate)
+				}
+			}
+			results <- res
+		}
+	}
+}
+
+func scanConnect(ctx context.Context, job Job, timeout time.Duration, bannerReadBytes int, registry *proberRegistry, probers map[string]Prober) (ScanResult, bool) {
+	addr := fmt.Sprintf("%s:%d", job.Host, job.Port)
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	res := ScanResult{Host: job.Host, Port: job.Port}
+	if err != nil {
+		res.State = StateClosed
+		return res, isDialTimeout(err)
+	}
+	defer conn.Close()
+	res.State = StateOpen
+
+	probed := false
+	if prober := registry.forPort(job.Port, probers); prober != nil {
+		if details, err := prober.Probe(ctx, conn, job.Host, job.Port); err == nil {
+			res.Service = prober.Name()
+			res.Details = details
+			probed = true
+		}
+	}
+	if !probed && bannerReadBytes > 0 {
+		conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+		buf := make([]byte, bannerReadBytes)
+		n, _ := conn.Read(buf)
+		if n > 0 {
+			res.Banner = strings.TrimSpace(string(buf[:n]))
+		}
+	}
+	return res, false
+}
+
+func scanSYN(ctx context.Context, sock *synSocket, job Job, timeout time.Duration) ScanResult {
+	res := ScanResult{Host: job.Host, Port: job.Port}
+	state, err := sock.scan(ctx, job.Host, job.Port, timeout)
+	if err != nil {
+		res.State = StateFiltered
+		res.Details = map[string]any{"error": err.Error()}
+		return res
+	}
+	res.State = state
+	return res
+}
+
+func scanUDP(ctx context.Context, sock *icmpSocket, job Job, timeout time.Duration) ScanResult {
+	res := ScanResult{Host: job.Host, Port: job.Port}
+	state, err := sock.scan(ctx, job.Host, job.Port, timeout)
+	if err != nil {
+		res.State = StateOpenFiltered
+		res.Details = map[string]any{"error": err.Error()}
+		return res
+	}
+	res.State = state
+	return res
+}
+
+// produceJobs expands targets × ports into the jobs channel, skipping any
+// pair already present in the resume checkpoint, then closes jobs.
+func produceJobs(ctx context.Context, jobs chan<- Job, targets []Target, ports []int, done map[string]struct{}) {
+	defer close(jobs)
+	for _, t := range targets {
+		for _, p := range ports {
+			if done != nil {
+				if _, skip := done[checkpointKey(t.Host, p)]; skip {
+					continue
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- Job{Host: t.Host, Port: p}:
+			}
+		}
+	}
+}
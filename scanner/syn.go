@@ -0,0 +1,143 @@
+// port-scanner/scanner/syn.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+// synSocket is a single raw IP socket shared by every worker running a
+// --scan-type=syn scan. A raw socket can observe replies addressed to any
+// local port, so there is no need for one per job; opening one per job
+// just burns a raw socket per in-flight probe for no benefit, and can run
+// the process out of file descriptors well before --concurrency is
+// saturated. A background goroutine reads every inbound segment and
+// routes it to whichever probe is waiting on its source port.
+type synSocket struct {
+	conn *net.IPConn
+
+	mu      sync.Mutex
+	waiters map[uint16]*synWaiter
+}
+
+type synWaiter struct {
+	dst        net.IP
+	remotePort uint16
+	replies    chan parsedTCPHeader
+}
+
+// newSynSocket opens the shared raw socket and starts its dispatch loop.
+// Close it once the scan using it is done.
+func newSynSocket() (*synSocket, error) {
+	conn, err := net.ListenIP("ip4:tcp", &net.IPAddr{IP: net.IPv4zero})
+	if err != nil {
+		return nil, fmt.Errorf("opening raw TCP socket: %w", err)
+	}
+	s := &synSocket{conn: conn, waiters: make(map[uint16]*synWaiter)}
+	go s.dispatch()
+	return s, nil
+}
+
+func (s *synSocket) Close() error {
+	return s.conn.Close()
+}
+
+// dispatch reads every inbound segment on the shared socket until it's
+// closed, and forwards each one to the waiter registered for the segment's
+// destination port, if any.
+func (s *synSocket) dispatch() {
+	buf := make([]byte, 1500)
+	for {
+		n, raddr, err := s.conn.ReadFromIP(buf)
+		if err != nil {
+			return
+		}
+		hdr, ok := parseTCPHeader(buf[:n])
+		if !ok {
+			continue
+		}
+
+		s.mu.Lock()
+		w, ok := s.waiters[hdr.DstPort]
+		s.mu.Unlock()
+		if !ok || hdr.SrcPort != w.remotePort || !raddr.IP.Equal(w.dst) {
+			continue
+		}
+		select {
+		case w.replies <- hdr:
+		default:
+		}
+	}
+}
+
+// register reserves a random ephemeral source port for a probe against
+// dst:remotePort and returns it along with the channel that will receive
+// any matching reply. Callers must unregister once done.
+func (s *synSocket) register(dst net.IP, remotePort uint16) (uint16, chan parsedTCPHeader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		srcPort := uint16(40000 + rand.Intn(20000))
+		if _, taken := s.waiters[srcPort]; taken {
+			continue
+		}
+		w := &synWaiter{dst: dst, remotePort: remotePort, replies: make(chan parsedTCPHeader, 1)}
+		s.waiters[srcPort] = w
+		return srcPort, w.replies
+	}
+}
+
+func (s *synSocket) unregister(srcPort uint16) {
+	s.mu.Lock()
+	delete(s.waiters, srcPort)
+	s.mu.Unlock()
+}
+
+// scan performs a half-open TCP scan against host:port: it sends a SYN and
+// classifies the port from the reply without ever completing the
+// handshake.
+//
+// Known limitation: this process never holds a real socket on the source
+// port the probe uses, so the kernel's own TCP stack also observes any
+// SYN-ACK reply and answers it with an unsolicited RST before the target
+// expects one. Real SYN scanners work around this with a firewall rule
+// dropping the scanner's outbound RSTs; this implementation does not
+// attempt that, so results on a loaded or RST-sensitive target may be less
+// reliable than on read-only raw-socket captures.
+func (s *synSocket) scan(ctx context.Context, host string, port int, timeout time.Duration) (string, error) {
+	dstIP, err := resolveIPv4(host)
+	if err != nil {
+		return "", err
+	}
+	srcIP, err := outboundIPv4(dstIP)
+	if err != nil {
+		return "", err
+	}
+
+	srcPort, replies := s.register(dstIP, uint16(port))
+	defer s.unregister(srcPort)
+
+	segment := buildTCPSYN(srcIP, dstIP, srcPort, uint16(port), rand.Uint32())
+	if _, err := s.conn.WriteToIP(segment, &net.IPAddr{IP: dstIP}); err != nil {
+		return "", err
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case hdr := <-replies:
+		switch {
+		case hdr.Flags&tcpFlagRST != 0:
+			return "closed", nil
+		case hdr.Flags&tcpFlagSYN != 0 && hdr.Flags&tcpFlagACK != 0:
+			return "open", nil
+		}
+		return "filtered", nil
+	case <-time.After(timeout):
+		return "filtered", nil
+	}
+}
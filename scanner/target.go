@@ -0,0 +1,134 @@
+// port-scanner/scanner/target.go
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// Target is a single scan target, resolved from a host, a CIDR block, or a
+// line in an @file reference.
+type Target struct {
+	Host string
+}
+
+// ParseTargets expands a comma-separated --targets spec into a deduplicated
+// list of Targets. Each comma-separated element may be a bare host/IP, a
+// CIDR block (e.g. 10.0.0.0/24), or — when allowFileTargets is set — an
+// @file.txt reference listing one host per line.
+//
+// allowFileTargets must be false for any spec that didn't come from the
+// trusted CLI: an @file reference reads an arbitrary file the scanning
+// process can see and echoes its lines back as target hosts, so honoring it
+// from a network caller (e.g. scannerd's HTTP API) is an arbitrary local
+// file read.
+func ParseTargets(spec string, allowFileTargets bool) ([]Target, error) {
+	var targets []Target
+	seen := make(map[string]struct{})
+	add := func(h string) {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			return
+		}
+		if _, ok := seen[h]; ok {
+			return
+		}
+		seen[h] = struct{}{}
+		targets = append(targets, Target{Host: h})
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(part, "@"):
+			if !allowFileTargets {
+				return nil, fmt.Errorf("file-based target %q is not allowed here", part)
+			}
+			hosts, err := readHostsFile(part[1:])
+			if err != nil {
+				return nil, err
+			}
+			for _, h := range hosts {
+				add(h)
+			}
+		case strings.Contains(part, "/"):
+			hosts, err := expandCIDR(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q: %w", part, err)
+			}
+			for _, h := range hosts {
+				add(h)
+			}
+		default:
+			add(part)
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets specified")
+	}
+	return targets, nil
+}
+
+func readHostsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading target file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var hosts []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		hosts = append(hosts, line)
+	}
+	return hosts, sc.Err()
+}
+
+// maxCIDRHostBits bounds how large a single CIDR block ParseTargets will
+// expand. Without a cap, a block like 10.0.0.0/8 (or worse, 0.0.0.0/0)
+// materializes millions of host strings in one call, which is a
+// remotely triggerable resource-exhaustion risk now that scannerd passes
+// an untrusted caller's --targets spec straight through to ParseTargets.
+// 16 host bits (a /16, ~65k addresses) is generous for one scan.
+const maxCIDRHostBits = 16
+
+// expandCIDR enumerates every host address in a CIDR block, dropping the
+// network and broadcast addresses when the block is larger than a /31.
+func expandCIDR(cidr string) ([]string, error) {
+	ip, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	if ones, bits := ipnet.Mask.Size(); bits-ones > maxCIDRHostBits {
+		return nil, fmt.Errorf("block too large to expand (/%d; must be /%d or smaller)", ones, bits-maxCIDRHostBits)
+	}
+
+	var hosts []string
+	for cur := ip.Mask(ipnet.Mask); ipnet.Contains(cur); incIP(cur) {
+		hosts = append(hosts, cur.String())
+	}
+	if len(hosts) > 2 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return hosts, nil
+}
+
+func incIP(ip net.IP) {
+	for i := len(ip) - 1; i >= 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// port-scanner/scanner/target_test.go
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTargetsHostsAndCIDR(t *testing.T) {
+	targets, err := ParseTargets("10.0.0.1, 10.0.0.0/30, 10.0.0.1", false)
+	if err != nil {
+		t.Fatalf("ParseTargets: %v", err)
+	}
+
+	var hosts []string
+	for _, tg := range targets {
+		hosts = append(hosts, tg.Host)
+	}
+	// 10.0.0.1 appears both bare and inside the /30; it must be deduplicated,
+	// and the /30's network/broadcast addresses (.0 and .3) must be dropped.
+	want := []string{"10.0.0.1", "10.0.0.2"}
+	if len(hosts) != len(want) {
+		t.Fatalf("hosts = %v, want %v", hosts, want)
+	}
+	for i, h := range want {
+		if hosts[i] != h {
+			t.Errorf("hosts[%d] = %q, want %q", i, hosts[i], h)
+		}
+	}
+}
+
+func TestParseTargetsEmptySpec(t *testing.T) {
+	if _, err := ParseTargets("", false); err == nil {
+		t.Fatal("expected error for empty spec")
+	}
+}
+
+func TestParseTargetsRejectsOversizedCIDR(t *testing.T) {
+	_, err := ParseTargets("10.0.0.0/8", false)
+	if err == nil {
+		t.Fatal("expected /8 to be rejected as too large to expand")
+	}
+	if !strings.Contains(err.Error(), "too large") {
+		t.Errorf("error = %v, want it to mention the block is too large", err)
+	}
+}
+
+func TestExpandCIDRAtCap(t *testing.T) {
+	hosts, err := expandCIDR("10.0.0.0/16")
+	if err != nil {
+		t.Fatalf("expandCIDR(/16): %v", err)
+	}
+	const want = 1<<16 - 2 // /16 minus network and broadcast addresses
+	if len(hosts) != want {
+		t.Errorf("len(hosts) = %d, want %d", len(hosts), want)
+	}
+}
+
+func TestParseTargetsRejectsFileTargetsByDefault(t *testing.T) {
+	_, err := ParseTargets("@/etc/passwd", false)
+	if err == nil {
+		t.Fatal("expected an @file target to be rejected when allowFileTargets is false")
+	}
+	if !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("error = %v, want it to say file targets aren't allowed", err)
+	}
+}
+
+func TestParseTargetsAllowsFileTargetsWhenPermitted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.txt")
+	if err := os.WriteFile(path, []byte("10.0.0.1\n10.0.0.2\n"), 0o644); err != nil {
+		t.Fatalf("writing temp hosts file: %v", err)
+	}
+
+	targets, err := ParseTargets("@"+path, true)
+	if err != nil {
+		t.Fatalf("ParseTargets with allowFileTargets=true: %v", err)
+	}
+	if len(targets) != 2 {
+		t.Fatalf("targets = %v, want 2 hosts from the file", targets)
+	}
+}
+
+func TestExpandCIDRSmallBlock(t *testing.T) {
+	hosts, err := expandCIDR("192.168.1.0/31")
+	if err != nil {
+		t.Fatalf("expandCIDR(/31): %v", err)
+	}
+	// A /31 is too small to have distinct network/broadcast addresses, so
+	// both of its two addresses are usable.
+	want := []string{"192.168.1.0", "192.168.1.1"}
+	if len(hosts) != len(want) {
+		t.Fatalf("hosts = %v, want %v", hosts, want)
+	}
+}
@@ -0,0 +1,194 @@
+// port-scanner/scanner/udp.go
+package scanner
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/icmp"
+)
+
+// udpProbePayloads holds protocol-specific payloads for well-known UDP
+// services that stay silent on an empty datagram.
+var udpProbePayloads = map[int][]byte{
+	53:  dnsProbePayload(),
+	161: snmpProbePayload(),
+	123: ntpProbePayload(),
+}
+
+// dnsProbePayload is a minimal standard query for the root domain, type ANY.
+func dnsProbePayload() []byte {
+	return []byte{
+		0x12, 0x34, // transaction ID
+		0x01, 0x00, // flags: standard query, recursion desired
+		0x00, 0x01, // QDCOUNT
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // ANCOUNT, NSCOUNT, ARCOUNT
+		0x00,       // root name
+		0x00, 0x01, // QTYPE A
+		0x00, 0x01, // QCLASS IN
+	}
+}
+
+// snmpProbePayload is an SNMPv1 GetRequest for sysDescr.0, community "public".
+func snmpProbePayload() []byte {
+	return []byte{
+		0x30, 0x26, 0x02, 0x01, 0x00, 0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c',
+		0xa0, 0x19, 0x02, 0x01, 0x01, 0x02, 0x01, 0x00, 0x02, 0x01, 0x00,
+		0x30, 0x0e, 0x30, 0x0c, 0x06, 0x08, 0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x01, 0x00, 0x05, 0x00,
+	}
+}
+
+// ntpProbePayload is an NTPv3 client request (mode 3).
+func ntpProbePayload() []byte {
+	payload := make([]byte, 48)
+	payload[0] = 0x1b
+	return payload
+}
+
+// icmpSocket is a single raw ICMP listener shared by every worker running
+// a --scan-type=udp scan, so the process opens one raw socket per scan
+// rather than one per in-flight probe. A background goroutine reads every
+// inbound ICMP message and routes destination-unreachable replies to
+// whichever probe sent the datagram that triggered them.
+type icmpSocket struct {
+	conn *icmp.PacketConn
+
+	mu      sync.Mutex
+	waiters map[string]chan struct{} // keyed by checkpointKey(host, port)
+}
+
+// newICMPSocket opens the shared raw ICMP listener and starts its dispatch
+// loop. Close it once the scan using it is done.
+func newICMPSocket() (*icmpSocket, error) {
+	conn, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return nil, fmt.Errorf("opening raw ICMP listener: %w", err)
+	}
+	s := &icmpSocket{conn: conn, waiters: make(map[string]chan struct{})}
+	go s.dispatch()
+	return s, nil
+}
+
+func (s *icmpSocket) Close() error {
+	return s.conn.Close()
+}
+
+// dispatch reads every inbound ICMP message on the shared socket until
+// it's closed, and signals the waiter registered for a destination's
+// unreachable (host,port), if any.
+func (s *icmpSocket) dispatch() {
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		msg, err := icmp.ParseMessage(1, buf[:n]) // 1 = ICMP protocol number
+		if err != nil {
+			continue
+		}
+		du, ok := msg.Body.(*icmp.DstUnreach)
+		if !ok {
+			continue
+		}
+		dstIP, port, ok := parseUnreachableTarget(du.Data)
+		if !ok {
+			continue
+		}
+
+		s.mu.Lock()
+		ch, ok := s.waiters[checkpointKey(dstIP.String(), port)]
+		s.mu.Unlock()
+		if !ok {
+			continue
+		}
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// register reserves a waiter for an unreachable reply to host:port.
+// Callers must unregister once done.
+func (s *icmpSocket) register(host string, port int) (string, chan struct{}) {
+	key := checkpointKey(host, port)
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.waiters[key] = ch
+	s.mu.Unlock()
+	return key, ch
+}
+
+func (s *icmpSocket) unregister(key string) {
+	s.mu.Lock()
+	delete(s.waiters, key)
+	s.mu.Unlock()
+}
+
+// scan sends a UDP probe and classifies the port from any application
+// reply, or from an ICMP port-unreachable captured on the shared raw
+// listener, per RFC 1122 §3.2.2.1.
+func (s *icmpSocket) scan(ctx context.Context, host string, port int, timeout time.Duration) (string, error) {
+	dstIP, err := resolveIPv4(host)
+	if err != nil {
+		return "", err
+	}
+
+	udpConn, err := net.DialTimeout("udp4", net.JoinHostPort(dstIP.String(), fmt.Sprintf("%d", port)), timeout)
+	if err != nil {
+		return "", err
+	}
+	defer udpConn.Close()
+
+	key, unreachable := s.register(dstIP.String(), port)
+	defer s.unregister(key)
+
+	payload := udpProbePayloads[port]
+	if _, err := udpConn.Write(payload); err != nil {
+		return "", err
+	}
+
+	deadline := time.Now().Add(timeout)
+	replies := make(chan string, 1)
+	go func() {
+		udpConn.SetReadDeadline(deadline)
+		buf := make([]byte, 1500)
+		if n, _ := udpConn.Read(buf); n > 0 {
+			replies <- "open"
+		}
+	}()
+
+	select {
+	case state := <-replies:
+		return state, nil
+	case <-unreachable:
+		return "closed", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(time.Until(deadline)):
+		return "open-filtered", nil
+	}
+}
+
+// parseUnreachableTarget extracts the destination address and port of the
+// original datagram embedded in an ICMP destination-unreachable message —
+// since that datagram is the one we sent, its destination identifies which
+// probe the unreachable belongs to.
+func parseUnreachableTarget(embeddedIPPacket []byte) (dstIP net.IP, port int, ok bool) {
+	if len(embeddedIPPacket) < 20 {
+		return nil, 0, false
+	}
+	ihl := int(embeddedIPPacket[0]&0x0f) * 4
+	if len(embeddedIPPacket) < ihl+4 {
+		return nil, 0, false
+	}
+	dst := net.IP(embeddedIPPacket[16:20])
+	udpHeader := embeddedIPPacket[ihl:]
+	dstPort := binary.BigEndian.Uint16(udpHeader[2:4])
+	return dst, int(dstPort), true
+}
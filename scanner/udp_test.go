@@ -0,0 +1,50 @@
+// port-scanner/scanner/udp_test.go
+package scanner
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+)
+
+// embeddedUDPPacket builds a minimal IPv4 header (no options) followed by a
+// UDP header, as it would appear embedded in an ICMP destination-unreachable
+// message.
+func embeddedUDPPacket(dst net.IP, dstPort uint16) []byte {
+	buf := make([]byte, 20+8)
+	buf[0] = 0x45 // version 4, IHL 5 (20 bytes)
+	copy(buf[16:20], dst.To4())
+	binary.BigEndian.PutUint16(buf[20+2:20+4], dstPort)
+	return buf
+}
+
+func TestParseUnreachableTarget(t *testing.T) {
+	dst := net.ParseIP("192.0.2.10").To4()
+	pkt := embeddedUDPPacket(dst, 53)
+
+	gotIP, gotPort, ok := parseUnreachableTarget(pkt)
+	if !ok {
+		t.Fatal("parseUnreachableTarget returned ok=false for a well-formed packet")
+	}
+	if !gotIP.Equal(net.IP(dst)) {
+		t.Errorf("dstIP = %v, want %v", gotIP, net.IP(dst))
+	}
+	if gotPort != 53 {
+		t.Errorf("port = %d, want 53", gotPort)
+	}
+}
+
+func TestParseUnreachableTargetTooShort(t *testing.T) {
+	if _, _, ok := parseUnreachableTarget(make([]byte, 19)); ok {
+		t.Error("parseUnreachableTarget should reject a buffer shorter than an IPv4 header")
+	}
+}
+
+func TestParseUnreachableTargetTruncatedUDPHeader(t *testing.T) {
+	// A valid IP header but no room for the 4 bytes of UDP src/dst port.
+	buf := make([]byte, 20+2)
+	buf[0] = 0x45
+	if _, _, ok := parseUnreachableTarget(buf); ok {
+		t.Error("parseUnreachableTarget should reject a packet with a truncated UDP header")
+	}
+}